@@ -0,0 +1,240 @@
+package nll
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SpawnNamed registers sp with this Scope under name, with explicit
+// dependencies on the spawns named in deps. deps must name spawns already
+// registered via SpawnNamed or SpawnHealthAware in this Scope or one of its
+// ancestors. sp is only invoked once every dependency has signalled healthy
+// (see SignalHealthy, WaitHealthy) or, for dependencies spawned via plain
+// SpawnNamed, once that dependency's own Spawner has returned. Teardown order
+// on Scope.Exit follows the reverse of this dependency graph rather than the
+// strict reverse-insertion order used by unnamed spawns registered via Spawn.
+func (s *Scope) SpawnNamed(ctx context.Context, name string, deps []string, sp Spawner) error {
+	s.mut.Lock()
+	if s.state != active {
+		s.mut.Unlock()
+		return fmt.Errorf("cannot spawn in scope with state %q", s.state)
+	}
+	if _, exists := s.graph[name]; exists {
+		s.mut.Unlock()
+		return fmt.Errorf("spawn named %q is already registered in this scope", name)
+	}
+	if _, exists := s.health[name]; exists {
+		s.mut.Unlock()
+		return fmt.Errorf("spawn named %q is already registered in this scope", name)
+	}
+	if s.graph == nil {
+		s.graph = make(map[string][]string)
+	}
+	s.graph[name] = append([]string(nil), deps...)
+	if cyc := detectCycle(s.graph, name); cyc != nil {
+		delete(s.graph, name)
+		s.mut.Unlock()
+		return fmt.Errorf("dependency cycle detected: %s", strings.Join(cyc, " -> "))
+	}
+	s.mut.Unlock()
+
+	depRecs := make([]*healthRecord, 0, len(deps))
+	for _, dep := range deps {
+		rec := s.findHealthRecord(dep)
+		if rec == nil {
+			s.mut.Lock()
+			delete(s.graph, name)
+			s.mut.Unlock()
+			return fmt.Errorf("unknown dependency %q for spawn %q", dep, name)
+		}
+		depRecs = append(depRecs, rec)
+	}
+
+	for _, rec := range depRecs {
+		select {
+		case <-rec.healthy:
+		case <-rec.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	rec := newHealthRecord()
+	s.mut.Lock()
+	if s.health == nil {
+		s.health = make(map[string]*healthRecord)
+	}
+	s.health[name] = rec
+	s.mut.Unlock()
+
+	if err := s.spawn(ctx, sp, name, true, deps); err != nil {
+		rec.setState(HealthFailed)
+		s.mut.Lock()
+		delete(s.graph, name)
+		delete(s.health, name)
+		s.mut.Unlock()
+		return err
+	}
+	SignalHealthy(context.WithValue(ctx, healthCtxKey{}, rec))
+	return nil
+}
+
+// Graph returns a snapshot of the dependency DAG built up by calls to
+// SpawnNamed on this Scope, as a map of spawn name to the names of the
+// dependencies it was registered with.
+func (s *Scope) Graph() map[string][]string {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	out := make(map[string][]string, len(s.graph))
+	for name, deps := range s.graph {
+		out[name] = append([]string(nil), deps...)
+	}
+	return out
+}
+
+// findHealthRecord looks up name in this Scope's health registry, walking up
+// through ancestor Scopes if it is not found locally.
+func (s *Scope) findHealthRecord(name string) *healthRecord {
+	for cur := s; cur != nil; cur = cur.parent {
+		cur.mut.Lock()
+		rec, ok := cur.health[name]
+		cur.mut.Unlock()
+		if ok {
+			return rec
+		}
+	}
+	return nil
+}
+
+// detectCycle reports whether start can reach itself via graph's dependency
+// edges, returning the cyclic path if so.
+func detectCycle(graph map[string][]string, start string) []string {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	mark := make(map[string]int)
+	var path []string
+
+	var visit func(n string) []string
+	visit = func(n string) []string {
+		switch mark[n] {
+		case visiting:
+			return append(append([]string(nil), path...), n)
+		case done:
+			return nil
+		}
+		mark[n] = visiting
+		path = append(path, n)
+		for _, dep := range graph[n] {
+			if cyc := visit(dep); cyc != nil {
+				return cyc
+			}
+		}
+		path = path[:len(path)-1]
+		mark[n] = done
+		return nil
+	}
+	return visit(start)
+}
+
+// dependsOnGraph builds, for each entry index, the list of entry indices it
+// depends on. An unnamed spawn implicitly depends on the spawn immediately
+// preceding it, preserving the legacy strict reverse-insertion contract;
+// a spawn registered via SpawnNamed depends only on the spawns named in its
+// deps. Must be called with s.mut held.
+func (s *Scope) dependsOnGraph() [][]int {
+	n := len(s.entries)
+	dependsOn := make([][]int, n)
+	index := make(map[string]int, n)
+	for i, e := range s.entries {
+		if e.name != "" {
+			index[e.name] = i
+		}
+	}
+	for i, e := range s.entries {
+		if e.explicit {
+			for _, dep := range e.deps {
+				if j, ok := index[dep]; ok {
+					dependsOn[i] = append(dependsOn[i], j)
+				}
+			}
+		} else if i > 0 {
+			dependsOn[i] = []int{i - 1}
+		}
+	}
+	return dependsOn
+}
+
+// teardownGroups partitions s.entries into groups of indices that may be
+// reaped concurrently, ordered so that a group is only returned after every
+// group that depends on it. The groups are already in teardown order: the last
+// group registered (deepest in the dependency graph) comes first, so that
+// dependents are reaped before what they depend on. Must be called with
+// s.mut held.
+func (s *Scope) teardownGroups() [][]int {
+	n := len(s.entries)
+	dependsOn := s.dependsOnGraph()
+
+	level := make([]int, n)
+	done := make([]bool, n)
+	remaining := n
+	for remaining > 0 {
+		progressed := false
+		for i := 0; i < n; i++ {
+			if done[i] {
+				continue
+			}
+			ready := true
+			lvl := 0
+			for _, d := range dependsOn[i] {
+				if !done[d] {
+					ready = false
+					break
+				}
+				if level[d]+1 > lvl {
+					lvl = level[d] + 1
+				}
+			}
+			if ready {
+				level[i] = lvl
+				done[i] = true
+				remaining--
+				progressed = true
+			}
+		}
+		if !progressed {
+			// Registration-time cycle checks should make this unreachable;
+			// fall back to treating whatever remains as a single level.
+			lvl := 0
+			for i := 0; i < n; i++ {
+				if !done[i] {
+					level[i] = lvl
+					done[i] = true
+					remaining--
+				}
+			}
+		}
+	}
+
+	maxLevel := 0
+	for _, lvl := range level {
+		if lvl > maxLevel {
+			maxLevel = lvl
+		}
+	}
+	groups := make([][]int, maxLevel+1)
+	for i := 0; i < n; i++ {
+		groups[level[i]] = append(groups[level[i]], i)
+	}
+
+	teardown := make([][]int, 0, len(groups))
+	for l := len(groups) - 1; l >= 0; l-- {
+		if len(groups[l]) > 0 {
+			teardown = append(teardown, groups[l])
+		}
+	}
+	return teardown
+}
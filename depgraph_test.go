@@ -0,0 +1,69 @@
+package nll_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mmcshane/nll"
+)
+
+func TestSpawnNamedTeardownOrder(t *testing.T) {
+	var order []string
+	s := nll.NewScope()
+
+	spawn := func(name string, deps []string) {
+		err := s.SpawnNamed(context.TODO(), name, deps, func(context.Context) (nll.Reaper, error) {
+			return func(context.Context) error {
+				order = append(order, name)
+				return nil
+			}, nil
+		})
+		require(t, err == nil, "unexpected error spawning %q: %q", name, err)
+	}
+
+	spawn("db", nil)
+	spawn("http", []string{"db"})
+
+	require(t, s.Exit(context.TODO()) == nil, "unexpected error from Scope.Exit")
+	require(t, len(order) == 2 && order[0] == "http" && order[1] == "db",
+		"expected http to be reaped before db, got %v", order)
+}
+
+func TestSpawnNamedUnknownDependency(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnNamed(context.TODO(), "http", []string{"db"},
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err != nil, "expected an error for an unregistered dependency")
+}
+
+func TestSpawnNamedCycle(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnNamed(context.TODO(), "a", nil,
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err == nil, "unexpected error: %q", err)
+
+	err = s.SpawnNamed(context.TODO(), "b", []string{"a"},
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err == nil, "unexpected error: %q", err)
+
+	// "a" already ran so this would not actually be reachable in practice,
+	// but SpawnNamed should still reject a name that depends on itself.
+	err = s.SpawnNamed(context.TODO(), "c", []string{"c"},
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err != nil, "expected a dependency cycle error")
+}
+
+func TestGraph(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnNamed(context.TODO(), "db", nil,
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err == nil, "unexpected error: %q", err)
+
+	err = s.SpawnNamed(context.TODO(), "http", []string{"db"},
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err == nil, "unexpected error: %q", err)
+
+	g := s.Graph()
+	require(t, len(g["http"]) == 1 && g["http"][0] == "db",
+		"expected http's graph entry to depend on db, got %v", g["http"])
+}
@@ -3,8 +3,6 @@ package nll_test
 import (
 	"context"
 	"fmt"
-	"os"
-	"os/signal"
 	"syscall"
 	"time"
 
@@ -104,7 +102,7 @@ func spawnRequestWatchdog(ctx context.Context, s *nll.Scope, d time.Duration) er
 }
 
 func Example() {
-	mainscope := nll.NewScope()
+	mainscope := nll.NewSignalScope(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	svc := mustSpawnService(context.TODO(), mainscope)
 
 	fmt.Printf("svc.State() == %q\n", svc.State())
@@ -132,15 +130,15 @@ func Example() {
 	// svc.State() == "stopped"
 }
 
+// mainwait used to hand-roll its own signal.Notify/select just to learn that
+// a termination signal arrived; that's now handled for us by the
+// NewSignalScope that Example creates, which exits mainscope directly on
+// SIGINT/SIGTERM, so mainwait only needs to watch for an unhandled error or
+// the demo's own timeout.
 func mainwait(errs <-chan error) {
-	sigchan := make(chan os.Signal, 1)
-	signal.Notify(sigchan, syscall.SIGINT, syscall.SIGTERM)
-
 	select {
 	case err := <-errs:
 		fmt.Printf("unhandled error: %q", err)
-	case sig := <-sigchan:
-		fmt.Printf("received signal (%v)", sig)
 	case <-time.After(1 * time.Second):
 		fmt.Println("demo exits after 1 second")
 	}
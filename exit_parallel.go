@@ -0,0 +1,210 @@
+package nll
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ReapTiming records how long a single Reaper took to run during Scope.Exit.
+// Name is the spawn's name if it was registered via SpawnNamed or
+// SpawnHealthAware, or "reaper[i]" (i being its index among this Scope's
+// spawns) for an unnamed spawn.
+type ReapTiming struct {
+	Name     string
+	Duration time.Duration
+}
+
+// ExitReport aggregates the outcome of every Reaper invoked across a
+// Scope.Exit call and its descendent Scopes: every error returned, how long
+// each Reaper took, and which spawns were skipped because the Exit context
+// was cancelled before they could run. ExitReport implements error so it can
+// be compared against nil or reported like any other error.
+type ExitReport struct {
+	mu      sync.Mutex
+	Errors  []error
+	Timings []ReapTiming
+	Skipped []string
+}
+
+// Error joins the messages of every error in Errors with "; ", satisfying the
+// error interface. It returns the empty string if Errors is empty.
+func (r *ExitReport) Error() string {
+	msgs := make([]string, len(r.Errors))
+	for i, err := range r.Errors {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap exposes every collected error for use with errors.Is / errors.As.
+func (r *ExitReport) Unwrap() []error {
+	return r.Errors
+}
+
+func (r *ExitReport) recordResult(name string, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Timings = append(r.Timings, ReapTiming{Name: name, Duration: d})
+	if err != nil {
+		r.Errors = append(r.Errors, err)
+	}
+}
+
+func (r *ExitReport) recordSkipped(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Skipped = append(r.Skipped, name)
+}
+
+// WithParallelReap is an Exit option that reaps spawns at the same level of
+// the dependency graph (see SpawnNamed) concurrently, bounded by concurrency,
+// instead of the default strictly-sequential reverse-insertion order. Child
+// Scopes are still always fully exited before this Scope's own Reapers run. A
+// concurrency <= 1 behaves like the sequential default.
+func WithParallelReap(concurrency int) ExitOpt {
+	return func(cfg *exitCfg) {
+		if concurrency < 1 {
+			concurrency = 1
+		}
+		cfg.concurrency = concurrency
+	}
+}
+
+// WithAggregatedErrors is an Exit option that collects every error returned
+// by a Reaper across this Scope and its descendents into an *ExitReport, in
+// addition to passing each one to the error handler (see WithErrorHandler).
+// Use WithExitReport to observe that report once Exit completes.
+func WithAggregatedErrors() ExitOpt {
+	return func(cfg *exitCfg) {
+		cfg.aggregate = true
+	}
+}
+
+// WithExitReport is an Exit option that delivers the *ExitReport accumulated
+// by WithAggregatedErrors to report once Exit (and all of its descendent
+// Scope exits) completes. It implies WithAggregatedErrors.
+func WithExitReport(report func(*ExitReport)) ExitOpt {
+	return func(cfg *exitCfg) {
+		cfg.aggregate = true
+		cfg.onReport = report
+	}
+}
+
+func entryLabel(entries []spawnEntry, i int) string {
+	if entries[i].name != "" {
+		return entries[i].name
+	}
+	return fmt.Sprintf("reaper[%d]", i)
+}
+
+// reapGroups reaps each teardown group in order, running the members of a
+// group concurrently when ec.concurrency > 1. It is only invoked from within
+// Scope.exit, which holds s.mut for the entirety of the call (including
+// this one), so a Reaper must never call back into a locking Scope method on
+// the same Scope or its ancestors or it will deadlock. Under
+// WithParallelReap, ec.onError (see WithErrorHandler) and any *ExitReport
+// (see WithAggregatedErrors, WithExitReport) are written to from multiple
+// reapGroupParallel goroutines concurrently: a caller-supplied onError func
+// must be safe to call concurrently, and ExitReport already guards its own
+// state with a mutex.
+func (s *Scope) reapGroups(ctx context.Context, ec *exitCfg, groups [][]int) error {
+	for gi, group := range groups {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			s.recordSkippedGroups(ec, groups[gi:])
+			return ctxerr
+		}
+		var err error
+		if ec.concurrency > 1 && len(group) > 1 {
+			err = s.reapGroupParallel(ctx, ec, group)
+		} else {
+			err = s.reapGroupSequential(ctx, ec, group)
+		}
+		if err != nil {
+			s.recordSkippedGroups(ec, groups[gi+1:])
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Scope) reapGroupSequential(ctx context.Context, ec *exitCfg, group []int) error {
+	for gi := len(group) - 1; gi >= 0; gi-- {
+		if err := s.reapOne(ctx, ec, group[gi]); err != nil {
+			return err
+		}
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			return ctxerr
+		}
+	}
+	return nil
+}
+
+func (s *Scope) reapGroupParallel(ctx context.Context, ec *exitCfg, group []int) error {
+	sem := make(chan struct{}, ec.concurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+
+	for _, i := range group {
+		mu.Lock()
+		abort := firstErr != nil
+		mu.Unlock()
+		if abort {
+			if ec.report != nil {
+				ec.report.recordSkipped(entryLabel(s.entries, i))
+			}
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := s.reapOne(ctx, ec, i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(i)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+func (s *Scope) reapOne(ctx context.Context, ec *exitCfg, i int) error {
+	start := time.Now()
+	err := s.callReaper(s.reapers[i], ctx, s.effectivePanicHandler(ec))
+	dur := time.Since(start)
+
+	if err != nil && err != ctx.Err() {
+		ec.onError(err)
+	}
+	if ec.report != nil {
+		var recorded error
+		if err != nil && err != ctx.Err() {
+			recorded = err
+		}
+		ec.report.recordResult(entryLabel(s.entries, i), dur, recorded)
+	}
+	if ctxerr := ctx.Err(); ctxerr != nil {
+		return ctxerr
+	}
+	return nil
+}
+
+func (s *Scope) recordSkippedGroups(ec *exitCfg, groups [][]int) {
+	if ec.report == nil {
+		return
+	}
+	for _, group := range groups {
+		for _, i := range group {
+			ec.report.recordSkipped(entryLabel(s.entries, i))
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package nll_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/nll"
+)
+
+func TestAggregatedErrors(t *testing.T) {
+	s := nll.NewScope()
+	e1 := errors.New("first")
+	e2 := errors.New("second")
+	s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error { return e1 }, nil
+	})
+	s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error { return e2 }, nil
+	})
+
+	var report *nll.ExitReport
+	err := s.Exit(context.TODO(),
+		nll.WithAggregatedErrors(),
+		nll.WithExitReport(func(r *nll.ExitReport) { report = r }))
+
+	require(t, err == nil, "unexpected error from Scope.Exit: %q", err)
+	require(t, report != nil, "expected an ExitReport to be delivered")
+	require(t, len(report.Errors) == 2, "expected both reaper errors aggregated, got %d", len(report.Errors))
+	require(t, len(report.Timings) == 2, "expected one timing per reaper, got %d", len(report.Timings))
+}
+
+func TestParallelReapRunsSiblingsConcurrently(t *testing.T) {
+	s := nll.NewScope()
+
+	start := make(chan struct{})
+	var mu sync.Mutex
+	entered := 0
+	reaper := func(context.Context) error {
+		mu.Lock()
+		entered++
+		n := entered
+		mu.Unlock()
+		if n == 2 {
+			close(start)
+		}
+		select {
+		case <-start:
+		case <-time.After(time.Second):
+			t.Errorf("expected sibling reapers to run concurrently under WithParallelReap")
+		}
+		return nil
+	}
+
+	spawn := func(name string) {
+		err := s.SpawnNamed(context.TODO(), name, nil, func(context.Context) (nll.Reaper, error) {
+			return reaper, nil
+		})
+		require(t, err == nil, "unexpected error: %q", err)
+	}
+	spawn("a")
+	spawn("b")
+
+	err := s.Exit(context.TODO(), nll.WithParallelReap(2))
+	require(t, err == nil, "unexpected error from Scope.Exit: %q", err)
+}
+
+func TestParallelReapSkipsAfterCancellation(t *testing.T) {
+	s := nll.NewScope()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err := s.SpawnNamed(ctx, "db", nil,
+		func(context.Context) (nll.Reaper, error) { return nilReaper, nil })
+	require(t, err == nil, "unexpected error: %q", err)
+
+	err = s.SpawnNamed(ctx, "http", []string{"db"}, func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error { cancel(); return nil }, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	var report *nll.ExitReport
+	err = s.Exit(ctx,
+		nll.WithParallelReap(2),
+		nll.WithExitReport(func(r *nll.ExitReport) { report = r }))
+
+	require(t, err == ctx.Err(), "expected context cancellation error, got %q", err)
+	require(t, report != nil, "expected an ExitReport")
+	require(t, len(report.Skipped) == 1 && report.Skipped[0] == "db",
+		"expected db to be reported skipped, got %v", report.Skipped)
+}
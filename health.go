@@ -0,0 +1,163 @@
+package nll
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// HealthState describes the lifecycle stage of a spawn registered via
+// Scope.SpawnHealthAware or Scope.SpawnNamed.
+type HealthState string
+
+const (
+	// HealthNew is the state of a spawn that has not yet signalled healthy
+	// or done.
+	HealthNew HealthState = "new"
+	// HealthHealthy is the state of a spawn once it has called
+	// SignalHealthy.
+	HealthHealthy HealthState = "healthy"
+	// HealthDone is the state of a spawn once it has called SignalDone.
+	HealthDone HealthState = "done"
+	// HealthFailed is the state of a spawn whose Spawner returned an error
+	// before ever signalling healthy or done.
+	HealthFailed HealthState = "failed"
+)
+
+// HealthAwareSpawner has the same shape as Spawner but is expected to signal
+// its lifecycle via nll.SignalHealthy and nll.SignalDone, called with the
+// context passed into this func (or a context derived from it).
+type HealthAwareSpawner func(context.Context) (Reaper, error)
+
+type healthRecord struct {
+	mu      sync.Mutex
+	state   HealthState
+	healthy chan struct{}
+	done    chan struct{}
+}
+
+func newHealthRecord() *healthRecord {
+	return &healthRecord{state: HealthNew, healthy: make(chan struct{}), done: make(chan struct{})}
+}
+
+func (r *healthRecord) setState(st HealthState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.state = st
+}
+
+func (r *healthRecord) get() HealthState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.state
+}
+
+type healthCtxKey struct{}
+
+// SignalHealthy marks the spawn associated with ctx (the context supplied to
+// a HealthAwareSpawner) as healthy, unblocking any concurrent call to
+// Scope.WaitHealthy that is waiting on it. It is a no-op if ctx was not
+// obtained from a HealthAwareSpawner.
+func SignalHealthy(ctx context.Context) {
+	rec, ok := ctx.Value(healthCtxKey{}).(*healthRecord)
+	if !ok {
+		return
+	}
+	rec.mu.Lock()
+	if rec.state == HealthNew {
+		rec.state = HealthHealthy
+	}
+	rec.mu.Unlock()
+	select {
+	case <-rec.healthy:
+	default:
+		close(rec.healthy)
+	}
+}
+
+// SignalDone marks the spawn associated with ctx (the context supplied to a
+// HealthAwareSpawner) as done, unblocking any concurrent call to
+// Scope.WaitHealthy that is waiting on it. It is a no-op if ctx was not
+// obtained from a HealthAwareSpawner.
+func SignalDone(ctx context.Context) {
+	rec, ok := ctx.Value(healthCtxKey{}).(*healthRecord)
+	if !ok {
+		return
+	}
+	rec.setState(HealthDone)
+	select {
+	case <-rec.healthy:
+	default:
+		close(rec.healthy)
+	}
+	select {
+	case <-rec.done:
+	default:
+		close(rec.done)
+	}
+}
+
+// SpawnHealthAware registers sp with this Scope under name, threading a
+// lifecycle context through sp that it (or a goroutine it starts) can use
+// with SignalHealthy and SignalDone to report its readiness. This replaces
+// ad-hoc `ready chan struct{}` bookkeeping with state observable via
+// Scope.Health and awaitable via Scope.WaitHealthy.
+func (s *Scope) SpawnHealthAware(ctx context.Context, name string, sp HealthAwareSpawner) error {
+	rec := newHealthRecord()
+
+	s.mut.Lock()
+	if s.state != active {
+		s.mut.Unlock()
+		return fmt.Errorf("cannot spawn in scope with state %q", s.state)
+	}
+	if s.health == nil {
+		s.health = make(map[string]*healthRecord)
+	}
+	s.health[name] = rec
+	s.mut.Unlock()
+
+	lifecycle := context.WithValue(ctx, healthCtxKey{}, rec)
+	if err := s.spawn(lifecycle, Spawner(sp), name, false, nil); err != nil {
+		rec.setState(HealthFailed)
+		return err
+	}
+	return nil
+}
+
+// Health returns a snapshot of every spawn-name to HealthState pair
+// registered on this Scope via SpawnHealthAware or SpawnNamed.
+func (s *Scope) Health() map[string]HealthState {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	out := make(map[string]HealthState, len(s.health))
+	for name, rec := range s.health {
+		out[name] = rec.get()
+	}
+	return out
+}
+
+// WaitHealthy blocks until every spawn currently registered on this Scope via
+// SpawnHealthAware or SpawnNamed has signalled healthy or done, or until ctx
+// expires. A spawn registered via SpawnNamed counts as healthy once its
+// Spawner has returned. A
+// spawn that signals done without ever signalling healthy also satisfies the
+// wait, since it will never become healthy after that point.
+func (s *Scope) WaitHealthy(ctx context.Context) error {
+	s.mut.Lock()
+	recs := make([]*healthRecord, 0, len(s.health))
+	for _, rec := range s.health {
+		recs = append(recs, rec)
+	}
+	s.mut.Unlock()
+
+	for _, rec := range recs {
+		select {
+		case <-rec.healthy:
+		case <-rec.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
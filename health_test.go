@@ -0,0 +1,56 @@
+package nll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/nll"
+)
+
+func TestWaitHealthy(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnHealthAware(context.TODO(), "svc", func(ctx context.Context) (nll.Reaper, error) {
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			nll.SignalHealthy(ctx)
+		}()
+		return nilReaper, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	require(t, s.Health()["svc"] == nll.HealthNew,
+		"expected svc to start in the %q state", nll.HealthNew)
+
+	err = s.WaitHealthy(context.Background())
+	require(t, err == nil, "unexpected error from WaitHealthy: %q", err)
+	require(t, s.Health()["svc"] == nll.HealthHealthy,
+		"expected svc to be %q after WaitHealthy returns", nll.HealthHealthy)
+}
+
+func TestWaitHealthyTimeout(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnHealthAware(context.TODO(), "svc", func(ctx context.Context) (nll.Reaper, error) {
+		return nilReaper, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	ctx, cncl := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cncl()
+	err = s.WaitHealthy(ctx)
+	require(t, err == ctx.Err(), "expected context deadline error, got %q", err)
+}
+
+func TestSignalDoneSatisfiesWaitHealthy(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnHealthAware(context.TODO(), "svc", func(ctx context.Context) (nll.Reaper, error) {
+		nll.SignalDone(ctx)
+		return nilReaper, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	err = s.WaitHealthy(context.Background())
+	require(t, err == nil, "unexpected error from WaitHealthy: %q", err)
+	require(t, s.Health()["svc"] == nll.HealthDone,
+		"expected svc to be %q, got %q", nll.HealthDone, s.Health()["svc"])
+}
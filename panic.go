@@ -0,0 +1,97 @@
+package nll
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicHandler is notified whenever Scope recovers a panic that would
+// otherwise have crashed the process -- from a Spawner, a Reaper, a
+// SupervisedSpawner run by SpawnSupervised, or a goroutine started via
+// SafeGo. phase is one of "spawn", "reap", "supervise", or "goroutine",
+// identifying where recovered was raised.
+type PanicHandler func(scope *Scope, phase string, recovered interface{}, stack []byte)
+
+// WithPanicHandler yields a ScopeOpt that installs h as this Scope's
+// PanicHandler. Child scopes created via NewChildScope inherit h unless they
+// specify their own. Regardless of whether a handler is installed, panics
+// from a Spawner or Reaper are always recovered; installing a handler simply
+// makes them observable instead of being silently swallowed.
+func WithPanicHandler(h PanicHandler) ScopeOpt {
+	return func(s *Scope) { s.panicHandler = h }
+}
+
+// WithExitPanicHandler yields an ExitOpt that installs h as the PanicHandler
+// used for Reaper panics recovered during this one call to Scope.Exit,
+// overriding (but not replacing) the Scope's own PanicHandler set via
+// WithPanicHandler.
+func WithExitPanicHandler(h PanicHandler) ExitOpt {
+	return func(cfg *exitCfg) { cfg.panicHandler = h }
+}
+
+// callSpawner invokes sp, recovering any panic it raises (directly, not in a
+// background goroutine it starts -- see SafeGo for that case) and reporting
+// it to s.panicHandler as phase "spawn".
+func (s *Scope) callSpawner(sp Spawner, ctx context.Context) (r Reaper, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			stack := debug.Stack()
+			s.panicHandler(s, "spawn", rec, stack)
+			err = fmt.Errorf("recovered panic in Spawner: %v", rec)
+		}
+	}()
+	return sp(ctx)
+}
+
+// callReaper invokes r, recovering any panic it raises and reporting it to
+// handler as phase "reap". handler is the effective PanicHandler for this
+// Exit call: ec.panicHandler if set, otherwise s.panicHandler.
+func (s *Scope) callReaper(r Reaper, ctx context.Context, handler PanicHandler) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			stack := debug.Stack()
+			handler(s, "reap", rec, stack)
+			err = fmt.Errorf("recovered panic in Reaper: %v", rec)
+		}
+	}()
+	return r(ctx)
+}
+
+// callSupervised invokes sp, recovering any panic it raises and reporting it
+// to s.panicHandler as phase "supervise". It is used by SpawnSupervised's
+// restart loop, where an unrecovered panic would otherwise take down the
+// whole process on every restart attempt.
+func (s *Scope) callSupervised(sp SupervisedSpawner, ctx context.Context) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			stack := debug.Stack()
+			s.panicHandler(s, "supervise", rec, stack)
+			err = fmt.Errorf("recovered panic in SupervisedSpawner: %v", rec)
+		}
+	}()
+	return sp(ctx)
+}
+
+func (s *Scope) effectivePanicHandler(ec *exitCfg) PanicHandler {
+	if ec.panicHandler != nil {
+		return ec.panicHandler
+	}
+	return s.panicHandler
+}
+
+// SafeGo starts fn in a new goroutine, recovering any panic it raises instead
+// of crashing the process. A recovered panic is reported to scope's
+// PanicHandler as phase "goroutine" and delivered as an error on scope.Err().
+func SafeGo(scope *Scope, fn func()) {
+	go func() {
+		defer func() {
+			if rec := recover(); rec != nil {
+				stack := debug.Stack()
+				scope.panicHandler(scope, "goroutine", rec, stack)
+				scope.reportAsyncErr(fmt.Errorf("recovered panic in SafeGo goroutine: %v", rec))
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,59 @@
+package nll_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/nll"
+)
+
+func TestSpawnPanicRecovered(t *testing.T) {
+	var gotPhase string
+	var gotRecovered interface{}
+	s := nll.NewScope(nll.WithPanicHandler(
+		func(scope *nll.Scope, phase string, recovered interface{}, stack []byte) {
+			gotPhase = phase
+			gotRecovered = recovered
+		}))
+
+	err := s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		panic("boom")
+	})
+
+	require(t, err != nil, "expected a panic in a Spawner to surface as an error")
+	require(t, gotPhase == "spawn", "expected panic handler phase %q, got %q", "spawn", gotPhase)
+	require(t, gotRecovered == "boom", "expected recovered value %q, got %v", "boom", gotRecovered)
+}
+
+func TestReaperPanicRecovered(t *testing.T) {
+	var gotPhase string
+	s := nll.NewScope(nll.WithPanicHandler(
+		func(scope *nll.Scope, phase string, recovered interface{}, stack []byte) {
+			gotPhase = phase
+		}))
+
+	err := s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error { panic("boom") }, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	var handlerErr error
+	err = s.Exit(context.TODO(), nll.WithErrorHandler(func(err error) { handlerErr = err }))
+
+	require(t, err == nil, "expected Scope.Exit to recover the panic rather than propagate it")
+	require(t, handlerErr != nil, "expected the error handler to observe the recovered panic")
+	require(t, gotPhase == "reap", "expected panic handler phase %q, got %q", "reap", gotPhase)
+}
+
+func TestSafeGoRecoversPanic(t *testing.T) {
+	s := nll.NewScope()
+	nll.SafeGo(s, func() { panic("boom") })
+
+	select {
+	case err := <-s.Err():
+		require(t, err != nil, "expected a non-nil error from the panicking goroutine")
+	case <-time.After(time.Second):
+		t.Fatalf("expected SafeGo to deliver the recovered panic on Scope.Err()")
+	}
+}
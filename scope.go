@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Reaper is a func type that reclaims the resources from a previously spawned
@@ -31,11 +32,25 @@ type Scoper func(...ScopeOpt) *Scope
 // onto a set of Reapers and child Scopes for execution at some dynamically
 // determined point in the future (by calling Scope.Exit).
 type Scope struct {
-	mut      sync.Mutex
-	state    state
-	children []*Scope
-	reapers  []Reaper
-	errors   chan error
+	mut          sync.Mutex
+	state        state
+	parent       *Scope
+	children     []*Scope
+	reapers      []Reaper
+	entries      []spawnEntry
+	errors       chan error
+	health       map[string]*healthRecord
+	graph        map[string][]string
+	panicHandler PanicHandler
+	signalGrace  time.Duration
+}
+
+// spawnEntry tracks the bookkeeping needed to order teardown correctly. It
+// sits alongside Scope.reapers, one entry per spawn, in the same order.
+type spawnEntry struct {
+	name     string
+	explicit bool
+	deps     []string
 }
 
 // ScopeOpt is a type for optional parameters to the Scope constructors.
@@ -54,8 +69,9 @@ func WithErrorChan(errs chan error) ScopeOpt {
 // immediately usable and remains so until Scope.Exit is invoked.
 func NewScope(opts ...ScopeOpt) *Scope {
 	s := &Scope{
-		state:  active,
-		errors: make(chan error),
+		state:        active,
+		errors:       make(chan error),
+		panicHandler: func(*Scope, string, interface{}, []byte) {},
 	}
 	for _, opt := range opts {
 		opt(s)
@@ -75,7 +91,12 @@ func (s *Scope) NewChildScope(opts ...ScopeOpt) *Scope {
 	if s.state != active {
 		return s
 	}
-	child := NewScope(opts...)
+	child := NewScope()
+	child.parent = s
+	child.panicHandler = s.panicHandler
+	for _, opt := range opts {
+		opt(child)
+	}
 	s.children = append(s.children, child)
 	return child
 }
@@ -85,22 +106,37 @@ func (s *Scope) NewChildScope(opts ...ScopeOpt) *Scope {
 // error is propagated as the retun value from this function. If this Scope has
 // already exited then this function will return an error.
 func (s *Scope) Spawn(ctx context.Context, sp Spawner) error {
+	return s.spawn(ctx, sp, "", false, nil)
+}
+
+// spawn is the shared implementation behind Spawn and SpawnNamed. name and
+// deps are bookkeeping only: an unnamed (explicit == false) spawn implicitly
+// depends on the spawn that preceded it, preserving the strict
+// reverse-insertion teardown order that predates named, explicitly ordered
+// spawns.
+func (s *Scope) spawn(ctx context.Context, sp Spawner, name string, explicit bool, deps []string) error {
 	s.mut.Lock()
 	defer s.mut.Unlock()
 	if s.state != active {
 		return fmt.Errorf("cannot spawn in scope with state %q", s.state)
 	}
-	r, err := sp(ctx)
+	r, err := s.callSpawner(sp, ctx)
 	if err != nil {
 		return err
 	}
 	s.reapers = append(s.reapers, r)
+	s.entries = append(s.entries, spawnEntry{name: name, explicit: explicit, deps: deps})
 	return nil
 }
 
 type exitCfg struct {
-	onError    func(err error)
-	maybeClose func(chan error)
+	onError      func(err error)
+	maybeClose   func(chan error)
+	concurrency  int
+	aggregate    bool
+	report       *ExitReport
+	onReport     func(*ExitReport)
+	panicHandler PanicHandler
 }
 
 // ExitOpt is a type for optional parameters to the Scope.Exit function.
@@ -109,6 +145,8 @@ type ExitOpt func(*exitCfg)
 // WithErrorHandler allows clients of Scope.Exit to supply a func that will be
 // notified of errors that are returned by calls to Reaper instances. Note that
 // this func does not allow for error propagation so the error must be handled.
+// If this Exit call is combined with WithParallelReap, eh may be called
+// concurrently from multiple goroutines and must be safe for that.
 func WithErrorHandler(eh func(err error)) ExitOpt {
 	return func(cfg *exitCfg) {
 		cfg.onError = eh
@@ -128,17 +166,26 @@ func WithCloseErrorChan() ExitOpt {
 // Exit terminates this Scope instance by recursively exiting its descendent
 // scopes in the reverse order of creation and then invoking all of it's managed
 // Reaper functions again in the reverse of the order in which they were
-// spawned. The *only* error emitted by this function is a if the supplied
+// spawned (or, for spawns registered via SpawnNamed, in reverse topological
+// order). The *only* error emitted by this function is a if the supplied
 // context.Context
 func (s *Scope) Exit(ctx context.Context, opts ...ExitOpt) error {
 	ec := exitCfg{
-		onError:    func(err error) {},
-		maybeClose: func(chan error) {},
+		onError:     func(err error) {},
+		maybeClose:  func(chan error) {},
+		concurrency: 1,
 	}
 	for _, opt := range opts {
 		opt(&ec)
 	}
-	return s.exit(ctx, &ec)
+	if ec.aggregate || ec.onReport != nil {
+		ec.report = &ExitReport{}
+	}
+	err := s.exit(ctx, &ec)
+	if ec.report != nil && ec.onReport != nil {
+		ec.onReport(ec.report)
+	}
+	return err
 }
 
 // Err observes this Scope's asynchronous error channel.
@@ -148,8 +195,10 @@ func (s *Scope) Err() chan error {
 
 func (s *Scope) exit(ctx context.Context, ec *exitCfg) error {
 	s.mut.Lock()
+	groups := s.teardownGroups()
 	defer func() {
 		s.reapers = make([]Reaper, 0)
+		s.entries = make([]spawnEntry, 0)
 		s.state = done
 		ec.maybeClose(s.errors)
 		s.mut.Unlock()
@@ -166,16 +215,7 @@ func (s *Scope) exit(ctx context.Context, ec *exitCfg) error {
 			return ctxerr
 		}
 	}
-	for i := len(s.reapers) - 1; i >= 0; i-- {
-		err := s.reapers[i](ctx)
-		if err != nil && err != ctx.Err() {
-			ec.onError(err)
-		}
-		if ctxerr := ctx.Err(); ctxerr != nil {
-			return ctxerr
-		}
-	}
-	return nil
+	return s.reapGroups(ctx, ec, groups)
 }
 
 // FmtErrHandler takes a common func prototype -- viz. `func(string,
@@ -0,0 +1,94 @@
+package nll
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// WithSignalGrace yields a ScopeOpt that sets the grace period given to
+// Scope.Exit when a signal-driven exit (see WithSignalExit, NewSignalScope)
+// is triggered. A value <= 0, the default, means no deadline: the exit waits
+// as long as its Reapers take, unless escalated by a second signal.
+func WithSignalGrace(d time.Duration) ScopeOpt {
+	return func(s *Scope) { s.signalGrace = d }
+}
+
+// WithSignalExit yields a ScopeOpt that installs an os/signal.Notify handler
+// for sigs (defaulting to os.Interrupt if none are supplied) and calls
+// Scope.Exit on the owning Scope when one arrives, using the grace period set
+// by WithSignalGrace. A second signal while that exit is in flight escalates
+// by cancelling the grace context immediately. The handler is unregistered
+// (via signal.Stop) when the Scope exits for any reason, whether or not a
+// signal ever arrived.
+func WithSignalExit(sigs ...os.Signal) ScopeOpt {
+	return func(s *Scope) {
+		s.installSignalExit(context.Background(), sigs...)
+	}
+}
+
+// NewSignalScope is a convenience constructor for a root Scope whose exit is
+// driven by either ctx being cancelled or one of sigs (defaulting to
+// os.Interrupt if none are supplied) being received, turning the
+// signal.Notify/select boilerplate this library's own Example once needed
+// into a one-liner.
+func NewSignalScope(ctx context.Context, sigs ...os.Signal) *Scope {
+	s := NewScope()
+	s.installSignalExit(ctx, sigs...)
+	return s
+}
+
+func (s *Scope) installSignalExit(upstream context.Context, sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt}
+	}
+	sigch := make(chan os.Signal, 2)
+	signal.Notify(sigch, sigs...)
+
+	quit := make(chan struct{})
+	stopped := make(chan struct{})
+
+	err := s.Spawn(context.Background(), func(context.Context) (Reaper, error) {
+		SafeGo(s, func() {
+			defer close(stopped)
+			select {
+			case <-sigch:
+			case <-upstream.Done():
+			case <-quit:
+				return
+			}
+
+			graceCtx, cancel := s.signalGraceContext()
+			go func() {
+				defer cancel()
+				s.Exit(graceCtx)
+			}()
+
+			select {
+			case <-sigch:
+				cancel()
+			case <-quit:
+			}
+		})
+
+		return func(context.Context) error {
+			signal.Stop(sigch)
+			close(quit)
+			<-stopped
+			return nil
+		}, nil
+	})
+	if err != nil {
+		// installSignalExit only ever runs against a freshly constructed,
+		// still-active Scope, so Spawn cannot fail here.
+		panic(err)
+	}
+}
+
+func (s *Scope) signalGraceContext() (context.Context, context.CancelFunc) {
+	if s.signalGrace <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), s.signalGrace)
+}
@@ -0,0 +1,87 @@
+package nll_test
+
+import (
+	"context"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/nll"
+)
+
+func waitFor(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestSignalExitTriggersExit(t *testing.T) {
+	var mu sync.Mutex
+	reaped := false
+
+	s := nll.NewScope(nll.WithSignalExit(syscall.SIGUSR1))
+	err := s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error {
+			mu.Lock()
+			reaped = true
+			mu.Unlock()
+			return nil
+		}, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	require(t, syscall.Kill(os.Getpid(), syscall.SIGUSR1) == nil,
+		"unexpected error sending signal")
+
+	waitFor(t, time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return reaped
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	require(t, reaped, "expected the signal to trigger Scope.Exit")
+}
+
+func TestSignalExitCleansUpOnDirectExit(t *testing.T) {
+	s := nll.NewScope(nll.WithSignalExit(syscall.SIGUSR2))
+	err := s.Exit(context.TODO())
+	require(t, err == nil, "unexpected error from Scope.Exit: %q", err)
+}
+
+func TestNewSignalScopeExitsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := nll.NewSignalScope(ctx)
+
+	var mu sync.Mutex
+	reaped := false
+	err := s.Spawn(context.TODO(), func(context.Context) (nll.Reaper, error) {
+		return func(context.Context) error {
+			mu.Lock()
+			reaped = true
+			mu.Unlock()
+			return nil
+		}, nil
+	})
+	require(t, err == nil, "unexpected error: %q", err)
+
+	cancel()
+
+	waitFor(t, time.Second, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return reaped
+	})
+
+	mu.Lock()
+	defer mu.Unlock()
+	require(t, reaped, "expected cancelling ctx to trigger Scope.Exit")
+}
@@ -0,0 +1,208 @@
+package nll
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// RestartPolicy governs whether a SupervisedSpawner is re-invoked after the
+// process it started terminates.
+type RestartPolicy int
+
+const (
+	// RestartNever leaves a terminated process reaped; its SupervisedSpawner
+	// is never re-invoked.
+	RestartNever RestartPolicy = iota
+	// RestartOnFailure re-invokes the SupervisedSpawner only when the
+	// process it started terminates with a non-nil error.
+	RestartOnFailure
+	// RestartAlways re-invokes the SupervisedSpawner every time the process
+	// it started terminates, regardless of error.
+	RestartAlways
+)
+
+// SupervisedSpawner launches an object or process and blocks until it
+// terminates, returning the error (if any) that caused the termination. The
+// supplied context is cancelled when the owning Scope reaps this spawn,
+// which SupervisedSpawner implementations should honor to return promptly.
+type SupervisedSpawner func(context.Context) error
+
+// BackoffPolicy configures the exponential backoff with jitter that
+// SpawnSupervised applies between restart attempts.
+type BackoffPolicy struct {
+	// Base is the delay before the first restart attempt.
+	Base time.Duration
+	// Max caps the delay applied before any single restart attempt.
+	Max time.Duration
+	// ResetAfter is how long a process must run without terminating before
+	// the next failure is treated as attempt 1 again rather than continuing
+	// to back off.
+	ResetAfter time.Duration
+}
+
+func (b BackoffPolicy) withDefaults() BackoffPolicy {
+	if b.Base <= 0 {
+		b.Base = 100 * time.Millisecond
+	}
+	if b.Max <= 0 {
+		b.Max = 30 * time.Second
+	}
+	if b.ResetAfter <= 0 {
+		b.ResetAfter = time.Minute
+	}
+	return b
+}
+
+// delay computes the backoff duration for the given restart attempt (1-based)
+// as a full-jitter exponential backoff bounded by Max.
+func (b BackoffPolicy) delay(attempt int) time.Duration {
+	d := b.Base
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= b.Max {
+			d = b.Max
+			break
+		}
+	}
+	if d > b.Max {
+		d = b.Max
+	}
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+// defaultCriticalMaxRestarts is the restart cap applied to a critical
+// supervised spawn that did not set its own via WithMaxRestarts, so that
+// WithCritical is meaningful even paired with the default of unlimited
+// restarts: without some cap, a critical spawn that never stops
+// failing would crash-loop forever and never propagate the shutdown
+// WithCritical promises.
+const defaultCriticalMaxRestarts = 5
+
+type supervisorCfg struct {
+	restart     RestartPolicy
+	backoff     BackoffPolicy
+	critical    bool
+	maxRestarts int
+}
+
+// SupervisorOpt is a type for optional parameters to Scope.SpawnSupervised.
+type SupervisorOpt func(*supervisorCfg)
+
+// WithRestartPolicy sets the RestartPolicy applied to a supervised spawn.
+// The default, when unspecified, is RestartOnFailure.
+func WithRestartPolicy(p RestartPolicy) SupervisorOpt {
+	return func(cfg *supervisorCfg) { cfg.restart = p }
+}
+
+// WithBackoff sets the BackoffPolicy applied between restart attempts.
+func WithBackoff(b BackoffPolicy) SupervisorOpt {
+	return func(cfg *supervisorCfg) { cfg.backoff = b.withDefaults() }
+}
+
+// WithCritical marks a supervised spawn as critical: once it gives up
+// restarting the owning Scope is exited in its entirety, tearing down its
+// other reapers and child Scopes just as a direct call to Scope.Exit would.
+// Giving up requires a restart cap: if WithMaxRestarts was not also supplied,
+// WithCritical installs defaultCriticalMaxRestarts so that a critical spawn
+// cannot crash-loop forever without ever propagating the shutdown this option
+// promises.
+func WithCritical() SupervisorOpt {
+	return func(cfg *supervisorCfg) { cfg.critical = true }
+}
+
+// WithMaxRestarts caps the number of restart attempts made for a supervised
+// spawn. Once exceeded, restarting stops and a "gave up after N restarts"
+// error is both delivered on Scope.Err() and returned from this spawn's
+// Reaper, so it also reaches WithErrorHandler (and any *ExitReport) the next
+// time the owning Scope exits. A value <= 0, the default, means no limit,
+// unless WithCritical is also set (see WithCritical).
+func WithMaxRestarts(n int) SupervisorOpt {
+	return func(cfg *supervisorCfg) { cfg.maxRestarts = n }
+}
+
+// SpawnSupervised registers sp with this Scope and runs it under a restart
+// policy: whenever the process sp starts terminates, sp is re-invoked
+// according to the configured RestartPolicy, backing off between attempts per
+// BackoffPolicy so that a crash loop does not spin. Reaping this spawn (via
+// Scope.Exit) cancels the context passed to sp and waits for it to return. If
+// restarting was abandoned because WithMaxRestarts was exceeded, the Reaper
+// returns the resulting "gave up after N restarts" error so it is reported
+// like any other Reaper error (see WithErrorHandler, WithExitReport) in
+// addition to being delivered on Scope.Err() as soon as it occurs.
+func (s *Scope) SpawnSupervised(ctx context.Context, sp SupervisedSpawner, opts ...SupervisorOpt) error {
+	cfg := supervisorCfg{restart: RestartOnFailure, backoff: BackoffPolicy{}.withDefaults()}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.critical && cfg.maxRestarts <= 0 {
+		cfg.maxRestarts = defaultCriticalMaxRestarts
+	}
+
+	return s.Spawn(ctx, func(context.Context) (Reaper, error) {
+		runctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan struct{})
+		var gaveUp error
+
+		go s.runSupervised(runctx, sp, cfg, stopped, &gaveUp)
+
+		return func(context.Context) error {
+			cancel()
+			<-stopped
+			return gaveUp
+		}, nil
+	})
+}
+
+func (s *Scope) runSupervised(ctx context.Context, sp SupervisedSpawner, cfg supervisorCfg, stopped chan struct{}, gaveUp *error) {
+	defer close(stopped)
+
+	attempt := 0
+	for {
+		started := time.Now()
+		err := s.callSupervised(sp, ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(started) >= cfg.backoff.ResetAfter {
+			attempt = 0
+		}
+
+		restart := cfg.restart == RestartAlways ||
+			(cfg.restart == RestartOnFailure && err != nil)
+		if !restart {
+			return
+		}
+
+		attempt++
+		if cfg.maxRestarts > 0 && attempt > cfg.maxRestarts {
+			*gaveUp = fmt.Errorf("gave up after %d restarts: %w", cfg.maxRestarts, err)
+			s.reportAsyncErr(*gaveUp)
+			if cfg.critical {
+				go s.Exit(context.Background())
+			}
+			return
+		}
+
+		select {
+		case <-time.After(cfg.backoff.delay(attempt)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reportAsyncErr delivers err on this Scope's error channel without blocking
+// the caller, mirroring the pattern used by callers that already send on
+// Scope.Err() from a background goroutine. If this Scope has already exited
+// with WithCloseErrorChan, Scope.errors may be closed by the time the send
+// below runs; the recover guards against the resulting "send on closed
+// channel" panic, matching the panic-safety the rest of this package applies
+// to Spawner/Reaper invocations (see panic.go).
+func (s *Scope) reportAsyncErr(err error) {
+	go func() {
+		defer func() { recover() }()
+		s.errors <- err
+	}()
+}
@@ -0,0 +1,69 @@
+package nll_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mmcshane/nll"
+)
+
+func TestSupervisedRestartOnFailure(t *testing.T) {
+	s := nll.NewScope()
+	runs := 0
+	done := make(chan struct{})
+	err := s.SpawnSupervised(context.TODO(), func(ctx context.Context) error {
+		runs++
+		if runs >= 3 {
+			close(done)
+			<-ctx.Done()
+			return nil
+		}
+		return errors.New("boom")
+	}, nll.WithBackoff(nll.BackoffPolicy{Base: time.Millisecond, Max: 5 * time.Millisecond}))
+	require(t, err == nil, "unexpected error: %q", err)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expected process to restart until it stopped failing, got %d runs", runs)
+	}
+
+	require(t, s.Exit(context.TODO()) == nil, "unexpected error from Scope.Exit")
+}
+
+func TestSupervisedRestartNever(t *testing.T) {
+	s := nll.NewScope()
+	runs := 0
+	ran := make(chan struct{})
+	err := s.SpawnSupervised(context.TODO(), func(ctx context.Context) error {
+		runs++
+		close(ran)
+		return errors.New("boom")
+	}, nll.WithRestartPolicy(nll.RestartNever))
+	require(t, err == nil, "unexpected error: %q", err)
+
+	<-ran
+	time.Sleep(20 * time.Millisecond)
+	require(t, runs == 1, "expected RestartNever to prevent restarts, got %d runs", runs)
+	require(t, s.Exit(context.TODO()) == nil, "unexpected error from Scope.Exit")
+}
+
+func TestSupervisedCriticalGivesUp(t *testing.T) {
+	s := nll.NewScope()
+	err := s.SpawnSupervised(context.TODO(), func(ctx context.Context) error {
+		return errors.New("boom")
+	},
+		nll.WithMaxRestarts(1),
+		nll.WithCritical(),
+		nll.WithBackoff(nll.BackoffPolicy{Base: time.Millisecond, Max: 2 * time.Millisecond}))
+	require(t, err == nil, "unexpected error: %q", err)
+
+	select {
+	case gotErr := <-s.Err():
+		require(t, gotErr != nil, "expected a non-nil gave-up error")
+	case <-time.After(time.Second):
+		t.Fatalf("expected a gave-up error on Scope.Err()")
+	}
+}